@@ -0,0 +1,114 @@
+package main
+
+// The toy problem the original framework shipped with: search for a number that has zero
+// difference from 50000, starting at zero and mutating by a random step each sweep. The chain
+// pool and temperature ladder are sized automatically (see mcmc.Config) rather than hand-tuned.
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "math"
+    "math/rand"
+    "time"
+
+    "github.com/fohristiwhirl/metropolis/mcmc"
+)
+
+// proposalsFor builds one proposal per chain from its temperature: colder chains get a small
+// Gaussian (local exploitation), and the hottest chain gets a heavy-tailed Zipf proposal (global
+// exploration) so it can hop the search out of a local optimum.
+func proposalsFor(temp []float64) []mcmc.Proposal {
+
+    proposals := make([]mcmc.Proposal, len(temp))
+
+    for n, t := range temp {
+        if n == len(temp) - 1 {
+            proposals[n] = &mcmc.ZipfProposal{S: 1.5, Max: 5000}
+        } else {
+            proposals[n] = mcmc.GaussianProposal{Sigma: 5 * t}
+        }
+    }
+
+    return proposals
+}
+
+type worldState struct {
+    World int32
+}
+
+type Find50000 struct{}
+
+func (Find50000) New() mcmc.State {
+    return &worldState{}
+}
+
+func (Find50000) Score(s mcmc.State) float64 {
+    world := s.(*worldState).World
+    diff := 50000 - world
+    if diff < 0 {
+        diff = -diff
+    }
+    return float64(diff)
+}
+
+func (Find50000) Mutate(s mcmc.State, r *rand.Rand, p mcmc.Proposal) mcmc.State {
+    w := s.(*worldState)
+    w.World += int32(math.Round(p.Step(r)))
+    return w
+}
+
+func (Find50000) Clone(s mcmc.State) mcmc.State {
+    clone := *s.(*worldState)
+    return &clone
+}
+
+func (Find50000) Encode(s mcmc.State) ([]byte, error) {
+    return json.Marshal(s.(*worldState))
+}
+
+func (Find50000) Decode(data []byte) (mcmc.State, error) {
+    var w worldState
+    if err := json.Unmarshal(data, &w); err != nil {
+        return nil, err
+    }
+    return &w, nil
+}
+
+func main() {
+    sampler := mcmc.NewSamplerFromConfig(Find50000{}, mcmc.Config{
+        TMin:     0.01,
+        TMax:     100,
+        Adaptive: true,
+    })
+    if err := sampler.SetProposals(proposalsFor(sampler.Temp)); err != nil {
+        fmt.Printf("invalid proposals: %v\n", err)
+        return
+    }
+    sampler.Results = make(chan mcmc.Result, 32)
+    sampler.CheckpointPath = "find50000.checkpoint.json"
+
+    logger_done := make(chan struct{})
+    go func() {
+        defer close(logger_done)
+        for result := range sampler.Results {
+            if result.Iter % 50 == 0 {
+                fmt.Printf("iter %5d  scores %v  swap accepts %v  proposal accepts %v\n", result.Iter, result.ChainScores, result.SwapAccepts, result.ProposalAccepts)
+            }
+        }
+    }()
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Minute)
+    defer cancel()
+
+    state, score, err := sampler.Run(ctx)
+    close(sampler.Results)
+    <- logger_done
+
+    if err != nil {
+        fmt.Printf("search did not finish: %v\n", err)
+        return
+    }
+
+    fmt.Printf("Success: World: %d (score: %.0f)\n", state.(*worldState).World, score)
+}
@@ -0,0 +1,121 @@
+package mcmc
+
+import (
+    "encoding/json"
+    "fmt"
+    "math/rand"
+    "os"
+)
+
+const defaultCheckpointEvery = 100          // sweeps between automatic checkpoints, if CheckpointPath is set
+
+// checkpointFile is what Checkpoint writes to disk and Resume reads back.
+type checkpointFile struct {
+    BaseSeed    int64
+    Temp        []float64
+    ChainStates [][]byte               // problem-encoded state per chain, in chain order
+
+    SwapInterval int
+
+    Adaptive     bool
+    TuneEvery    int
+    TargetAccept float64
+
+    CheckpointPath  string
+    CheckpointEvery int
+}
+
+// Checkpoint writes the ladder's seed/temperatures, the given per-chain states (in chain order),
+// and the Sampler's scalar tuning/checkpointing settings to path. A chain resumed from this file
+// restarts its RNG from its original per-chain seed rather than the exact in-flight stream
+// position, so a resumed chain's future random draws won't match what an uninterrupted run would
+// have drawn -- but the search itself picks up exactly where these states left off. Proposals are
+// not persisted (Proposal is an open interface; Checkpoint has no way to serialize an arbitrary
+// caller implementation), so a resumed Sampler falls back to NewSampler's default
+// UniformProposal{Width: 50} until the caller calls SetProposals again.
+func (s *Sampler) Checkpoint(path string, states []State) error {
+
+    cp := checkpointFile{
+        BaseSeed:    s.baseSeed,
+        Temp:        append([]float64(nil), s.Temp...),
+        ChainStates: make([][]byte, len(states)),
+
+        SwapInterval: s.SwapInterval,
+
+        Adaptive:     s.adaptive,
+        TuneEvery:    s.tuneEvery,
+        TargetAccept: s.targetAccept,
+
+        CheckpointPath:  s.CheckpointPath,
+        CheckpointEvery: s.CheckpointEvery,
+    }
+
+    for n, st := range states {
+        encoded, err := s.Problem.Encode(st)
+        if err != nil {
+            return fmt.Errorf("mcmc: encoding checkpoint state %d: %w", n, err)
+        }
+        cp.ChainStates[n] = encoded
+    }
+
+    f, err := os.Create(path)
+    if err != nil {
+        return fmt.Errorf("mcmc: creating checkpoint file: %w", err)
+    }
+    defer f.Close()
+
+    if err := json.NewEncoder(f).Encode(cp); err != nil {
+        return fmt.Errorf("mcmc: writing checkpoint file: %w", err)
+    }
+
+    return nil
+}
+
+// Resume rebuilds a Sampler from a file written by Checkpoint, restoring each chain to its
+// checkpointed state, temperature ladder, and scalar tuning/checkpointing settings (SwapInterval,
+// Adaptive, TuneEvery, TargetAccept, CheckpointPath, CheckpointEvery). The returned Sampler's Run
+// will seed chains from these states instead of calling problem.New(). See Checkpoint for the RNG
+// caveat and the Proposals caveat -- call SetProposals on the result if the original run used
+// anything other than the default UniformProposal.
+func Resume(path string, problem Problem) (*Sampler, error) {
+
+    f, err := os.Open(path)
+    if err != nil {
+        return nil, fmt.Errorf("mcmc: opening checkpoint file: %w", err)
+    }
+    defer f.Close()
+
+    var cp checkpointFile
+    if err := json.NewDecoder(f).Decode(&cp); err != nil {
+        return nil, fmt.Errorf("mcmc: decoding checkpoint file: %w", err)
+    }
+
+    s := NewSampler(problem, cp.Temp)
+    s.baseSeed = cp.BaseSeed
+    s.hubRNG = rand.New(rand.NewSource(s.baseSeed ^ int64(s.threads)))
+    for n := range s.rngs {
+        s.rngs[n].rng = rand.New(rand.NewSource(s.baseSeed ^ int64(n)))
+    }
+
+    if cp.SwapInterval > 0 {
+        s.SwapInterval = cp.SwapInterval
+    }
+
+    s.adaptive = cp.Adaptive
+    s.tuneEvery = cp.TuneEvery
+    s.targetAccept = cp.TargetAccept
+
+    s.CheckpointPath = cp.CheckpointPath
+    s.CheckpointEvery = cp.CheckpointEvery
+
+    s.resumeStates = make([]State, len(cp.ChainStates))
+    for n, encoded := range cp.ChainStates {
+        st, err := problem.Decode(encoded)
+        if err != nil {
+            return nil, fmt.Errorf("mcmc: decoding checkpoint state %d: %w", n, err)
+        }
+        s.resumeStates[n] = st
+    }
+
+    return s, nil
+}
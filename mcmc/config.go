@@ -0,0 +1,123 @@
+package mcmc
+
+import (
+    "math"
+    "runtime"
+)
+
+const (
+    defaultSwapInterval  = 10          // attempt a round of replica swaps every this many sweeps
+    defaultTuneEvery     = 10          // tune the ladder every this many exchange (swap) rounds
+    defaultTargetAccept  = 0.23        // the classic parallel-tempering target swap acceptance rate
+    tuneLearnRate        = 0.1         // how aggressively a single tune step moves a log-spacing
+    minLogSpacing        = 1e-6        // floor so two temperatures can never collapse to the same value
+)
+
+// Config describes how to auto-build a Sampler: how many chains to run and what temperature
+// ladder to give them, rather than the caller having to hand-tune both.
+type Config struct {
+    Threads int            // Number of chains. <= 0 defaults to runtime.NumCPU().
+    TMin    float64         // Coldest chain's temperature
+    TMax    float64         // Hottest chain's temperature
+
+    SwapInterval int        // Attempt a swap round every this many sweeps. <= 0 defaults to defaultSwapInterval.
+
+    Adaptive     bool       // If true, periodically re-space intermediate temperatures
+    TuneEvery    int        // Tune every this many exchange rounds. <= 0 defaults to defaultTuneEvery.
+    TargetAccept float64    // Target pairwise swap acceptance rate. <= 0 defaults to defaultTargetAccept.
+}
+
+// NewSamplerFromConfig builds a Sampler whose chain count and temperature ladder come from cfg
+// instead of being hand-specified. The ladder is geometric: T_i = TMin * (TMax/TMin)^(i/(N-1)).
+func NewSamplerFromConfig(problem Problem, cfg Config) *Sampler {
+
+    threads := cfg.Threads
+    if threads <= 0 {
+        threads = runtime.NumCPU()
+    }
+
+    s := NewSampler(problem, geometricLadder(cfg.TMin, cfg.TMax, threads))
+
+    if cfg.SwapInterval > 0 {
+        s.SwapInterval = cfg.SwapInterval
+    }
+
+    s.adaptive = cfg.Adaptive
+
+    s.tuneEvery = cfg.TuneEvery
+    if s.tuneEvery <= 0 {
+        s.tuneEvery = defaultTuneEvery
+    }
+
+    s.targetAccept = cfg.TargetAccept
+    if s.targetAccept <= 0 {
+        s.targetAccept = defaultTargetAccept
+    }
+
+    return s
+}
+
+func geometricLadder(tmin, tmax float64, n int) []float64 {
+
+    ladder := make([]float64, n)
+    if n == 1 {
+        ladder[0] = tmin
+        return ladder
+    }
+
+    for i := 0 ; i < n ; i++ {
+        frac := float64(i) / float64(n - 1)
+        ladder[i] = tmin * math.Pow(tmax / tmin, frac)
+    }
+
+    return ladder
+}
+
+// tune adjusts the intermediate temperatures towards a target pairwise swap acceptance rate,
+// using the attempt/accept counts observed since the last tune. T[0] and T[last] never move:
+// the log-spacings between them are nudged by (observed rate - target), then rescaled so they
+// still sum to the fixed log(TMax/TMin) span.
+func (s *Sampler) tune(attempts, accepts []int64) {
+
+    n := s.threads
+
+    logT := make([]float64, n)
+    for i := 0 ; i < n ; i++ {
+        logT[i] = math.Log(s.temp(i))
+    }
+
+    spacing := make([]float64, n - 1)
+    for i := 0 ; i < n - 1 ; i++ {
+        spacing[i] = logT[i + 1] - logT[i]
+
+        if attempts[i] > 0 {
+            rate := float64(accepts[i]) / float64(attempts[i])
+            spacing[i] += tuneLearnRate * (rate - s.targetAccept)
+        }
+
+        if spacing[i] < minLogSpacing {
+            spacing[i] = minLogSpacing
+        }
+    }
+
+    total := 0.0
+    for _, sp := range spacing {
+        total += sp
+    }
+
+    target_total := logT[n - 1] - logT[0]
+    if total > 0 {
+        scale := target_total / total
+        for i := range spacing {
+            spacing[i] *= scale
+        }
+    }
+
+    s.tempMu.Lock()
+    cum := logT[0]
+    for i := 0 ; i < n - 1 ; i++ {
+        cum += spacing[i]
+        s.Temp[i + 1] = math.Exp(cum)
+    }
+    s.tempMu.Unlock()
+}
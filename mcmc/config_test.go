@@ -0,0 +1,63 @@
+package mcmc
+
+import (
+    "math"
+    "testing"
+)
+
+func TestGeometricLadderEndpoints(t *testing.T) {
+
+    ladder := geometricLadder(0.1, 100, 5)
+
+    if ladder[0] != 0.1 {
+        t.Fatalf("expected ladder[0] == TMin, got %v", ladder[0])
+    }
+    if math.Abs(ladder[len(ladder) - 1] - 100) > 1e-9 {
+        t.Fatalf("expected ladder[last] == TMax, got %v", ladder[len(ladder) - 1])
+    }
+
+    for i := 1 ; i < len(ladder) ; i++ {
+        if ladder[i] <= ladder[i - 1] {
+            t.Fatalf("ladder not strictly increasing at %d: %v <= %v", i, ladder[i], ladder[i - 1])
+        }
+    }
+}
+
+func TestGeometricLadderSingleChain(t *testing.T) {
+
+    ladder := geometricLadder(0.5, 50, 1)
+
+    if len(ladder) != 1 || ladder[0] != 0.5 {
+        t.Fatalf("expected a single-chain ladder of [0.5], got %v", ladder)
+    }
+}
+
+// TestTunePreservesLogSpan exercises tune() directly against a synthetic set of attempt/accept
+// counts (well above, well below, and near the target rate), checking the invariant documented on
+// tune: the endpoints never move, and the log-spacings are rescaled so they still sum to the
+// original log(TMax/TMin) span.
+func TestTunePreservesLogSpan(t *testing.T) {
+
+    temp := geometricLadder(0.1, 100, 4)
+    sampler := NewSampler(findTarget{target: 0}, append([]float64(nil), temp...))
+    sampler.targetAccept = defaultTargetAccept
+
+    want_span := math.Log(temp[len(temp) - 1]) - math.Log(temp[0])
+
+    attempts := []int64{10, 10, 10}
+    accepts := []int64{9, 1, 2}
+
+    sampler.tune(attempts, accepts)
+
+    if sampler.Temp[0] != temp[0] {
+        t.Fatalf("tune must not move the coldest chain: got %v, want %v", sampler.Temp[0], temp[0])
+    }
+    if math.Abs(sampler.Temp[len(sampler.Temp) - 1] - temp[len(temp) - 1]) > 1e-9 {
+        t.Fatalf("tune must not move the hottest chain: got %v, want %v", sampler.Temp[len(sampler.Temp) - 1], temp[len(temp) - 1])
+    }
+
+    got_span := math.Log(sampler.Temp[len(sampler.Temp) - 1]) - math.Log(sampler.Temp[0])
+    if math.Abs(got_span - want_span) > 1e-9 {
+        t.Fatalf("tune changed the total log-span: got %v, want %v", got_span, want_span)
+    }
+}
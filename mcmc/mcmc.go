@@ -0,0 +1,395 @@
+// Package mcmc is a framework for metropolis-coupled search (parallel tempering). A Sampler runs
+// several chains in parallel, each a Metropolis chain at its own temperature, and periodically
+// attempts to swap adjacent chains' states using the replica-exchange criterion, so that hot
+// chains (which explore freely) occasionally hand a good state down to a cold chain (which
+// refines it).
+//
+// Users bring their own search space by implementing Problem; see examples/find50000 for the
+// simplest possible one.
+package mcmc
+
+import (
+    "context"
+    "fmt"
+    "math"
+    "math/rand"
+    "os"
+    "sync"
+    "time"
+)
+
+// State is an opaque value owned by a Problem. The Sampler never looks inside it.
+type State interface{}
+
+// Problem is the search space a Sampler explores. Lower scores are better; 0 means solved.
+type Problem interface {
+    New() State                                    // A fresh starting state
+    Score(State) float64                           // Lower is better, 0 is a solution
+    Mutate(State, *rand.Rand, Proposal) State       // Perturb the state using the chain's Proposal, may mutate in place and return it
+    Clone(State) State                              // A deep-enough copy that mutating the clone won't affect the original
+    Encode(State) ([]byte, error)                   // Serialize a state for Sampler.Checkpoint
+    Decode([]byte) (State, error)                   // The inverse of Encode, used by Resume
+}
+
+// Result is one sweep's worth of progress, sent on Sampler.Results for callers that want to log
+// or plot a running search instead of reading it off stdout.
+type Result struct {
+    Iter            int                // Sweep number this Result was produced on
+    ChainScores     []float64          // Every chain's current score, in chain order
+    BestState       State              // The best (lowest-score) state across all chains this sweep
+    SwapAccepts     []float64          // Cumulative swap accept rate per adjacent pair, as of this Iter
+    ProposalAccepts map[string]float64 // Cumulative mutation accept rate per proposal kernel name, as of this Iter
+    Temp            []float64          // Snapshot of the temperature ladder as of this Iter; the race-free way to observe Adaptive tuning in flight, since Sampler.Temp itself is only safe to read before Run starts
+}
+
+// entry is what travels on a Sampler's channels: a chain's current state and score, plus whether
+// the mutation that produced it was accepted, so the hub can tally per-kernel accept rates
+// without calling back into the Problem.
+type entry struct {
+    state    State
+    score    float64
+    accepted bool
+}
+
+// chainRNG is a chain's private random source. Each chain only ever touches its own chainRNG, so
+// no locking is needed; the padding keeps adjacent chains' (hot, frequently-written) entries on
+// separate cache lines so one chain's RNG use can't false-share with its neighbour's.
+type chainRNG struct {
+    rng *rand.Rand
+    _p1 [16]uint64
+}
+
+// Sampler owns the thread pool, temperature ladder, and channels for a parallel-tempering run
+// against a Problem.
+type Sampler struct {
+    Problem      Problem
+    Temp         []float64                  // Temp[0] should be coldest (most greedy). Safe to read directly before Run starts; once Run is running under Adaptive tuning, tune() mutates it under tempMu, so read Result.Temp instead
+    Proposals    []Proposal                 // Proposals[n] is the proposal kernel chain n mutates with
+    SwapInterval int                        // Attempt a swap round every this many sweeps
+
+    // Results, if non-nil, receives one Result per sweep. Sends are non-blocking: make it with
+    // whatever buffer suits your consumer, and a full channel just drops that sweep's Result
+    // rather than stalling the search.
+    Results chan Result
+
+    // CheckpointPath, if set, makes Run periodically write a checkpoint there (see Checkpoint)
+    // every CheckpointEvery sweeps (which defaults to defaultCheckpointEvery if <= 0).
+    CheckpointPath  string
+    CheckpointEvery int
+
+    threads  int
+    ptrChan  []chan *entry
+    rngs     []chainRNG                      // rngs[n] is used only by chain(n)
+    hubRNG   *rand.Rand                      // used only by the hub, for the swap roll
+    baseSeed int64
+
+    resumeStates []State                     // set by Resume; Run seeds chains from these instead of Problem.New()
+
+    tempMu sync.RWMutex                      // guards Temp while the hub's tune step is adapting it
+
+    adaptive     bool
+    tuneEvery    int
+    targetAccept float64
+}
+
+// temp reads chain i's current temperature. Chains and the hub's tune step may touch Temp
+// concurrently once adaptive tuning is enabled, so reads and writes both go through the mutex.
+func (s *Sampler) temp(i int) float64 {
+    s.tempMu.RLock()
+    defer s.tempMu.RUnlock()
+    return s.Temp[i]
+}
+
+// tempSnapshot copies the whole ladder under tempMu, for callers (namely sendResult) that need a
+// consistent view of every chain's temperature rather than one index at a time.
+func (s *Sampler) tempSnapshot() []float64 {
+    s.tempMu.RLock()
+    defer s.tempMu.RUnlock()
+    return append([]float64(nil), s.Temp...)
+}
+
+// NewSampler builds a Sampler for problem with one chain per entry in temp. Each chain gets its
+// own *rand.Rand seeded from baseSeed, so chains never contend on a shared Source. Every chain
+// defaults to a UniformProposal{Width: 50}; call SetProposals to mix in Gaussian or Zipf kernels.
+func NewSampler(problem Problem, temp []float64) *Sampler {
+
+    s := new(Sampler)
+    s.Problem = problem
+    s.Temp = temp
+    s.threads = len(temp)
+    s.SwapInterval = defaultSwapInterval
+    s.baseSeed = time.Now().UTC().UnixNano()
+    s.hubRNG = rand.New(rand.NewSource(s.baseSeed ^ int64(s.threads)))
+
+    s.ptrChan = make([]chan *entry, s.threads)
+    s.rngs = make([]chainRNG, s.threads)
+    s.Proposals = make([]Proposal, s.threads)
+    for n := 0 ; n < s.threads ; n++ {
+        s.ptrChan[n] = make(chan *entry)
+        s.rngs[n].rng = rand.New(rand.NewSource(s.baseSeed ^ int64(n)))
+        s.Proposals[n] = UniformProposal{Width: 50}
+    }
+
+    return s
+}
+
+// SetProposals overrides the per-chain proposal kernels. len(proposals) must equal the number of
+// chains; proposals[n] is used by chain n for every subsequent sweep. Returns an error instead of
+// installing the kernels if any of them is invalid (see validateProposals).
+func (s *Sampler) SetProposals(proposals []Proposal) error {
+    if err := validateProposals(proposals); err != nil {
+        return err
+    }
+
+    s.Proposals = proposals
+    return nil
+}
+
+// validator is implemented by proposal kernels that can be misconfigured in a way that would
+// otherwise only surface as a panic on first use (for example a ZipfProposal with S <= 1, for
+// which math/rand's NewZipf returns nil rather than an error).
+type validator interface {
+    Validate() error
+}
+
+// validateProposals checks every proposal that implements validator, so a misconfigured kernel is
+// rejected wherever it enters a Sampler -- through SetProposals, or through a direct assignment to
+// Proposals that Run then catches before starting any chain.
+func validateProposals(proposals []Proposal) error {
+    for n, p := range proposals {
+        if v, ok := p.(validator); ok {
+            if err := v.Validate(); err != nil {
+                return fmt.Errorf("mcmc: chain %d: %w", n, err)
+            }
+        }
+    }
+    return nil
+}
+
+// Run starts the chain goroutines and drives the hub loop until a zero-score state is found or
+// ctx is done, whichever comes first. On cancellation it returns ctx.Err(). Either way, Run
+// cancels its own child context before returning so every chain goroutine it started also exits.
+func (s *Sampler) Run(ctx context.Context) (State, float64, error) {
+
+    // Proposals may have been set by assigning straight to s.Proposals instead of going through
+    // SetProposals, so validate again here: this is the last point before any chain goroutine
+    // exists to panic on a misconfigured kernel.
+    if err := validateProposals(s.Proposals); err != nil {
+        return nil, 0, err
+    }
+
+    runCtx, cancel := context.WithCancel(ctx)
+    defer cancel()
+
+    for n := 0 ; n < s.threads ; n++ {
+        go s.chain(runCtx, n)
+
+        st := s.Problem.New()
+        if s.resumeStates != nil {
+            st = s.resumeStates[n]
+        }
+
+        select {
+        case s.ptrChan[n] <- &entry{state: st, score: s.Problem.Score(st)}:
+        case <- runCtx.Done():
+            return nil, 0, ctx.Err()
+        }
+    }
+
+    return s.hub(runCtx)
+}
+
+// ----------------------------------------------------------------- HUB (controller) and CHAIN (thread)
+
+func (s *Sampler) hub(ctx context.Context) (State, float64, error) {
+
+    entries := make([]*entry, s.threads)
+
+    // Lifetime counters, reported in every Result; never reset.
+    lifetime_swap_attempts := make([]int64, s.threads - 1)
+    lifetime_swap_accepts := make([]int64, s.threads - 1)
+
+    lifetime_proposal_attempts := make([]int64, s.threads)
+    lifetime_proposal_accepts := make([]int64, s.threads)
+
+    // Windowed counters, reset every time the adaptive tuner consumes them.
+    tune_attempts := make([]int64, s.threads - 1)
+    tune_accepts := make([]int64, s.threads - 1)
+
+    sweep := 0
+    swap_rounds := 0
+
+    checkpoint_every := s.CheckpointEvery
+    if checkpoint_every <= 0 {
+        checkpoint_every = defaultCheckpointEvery
+    }
+
+    for {
+
+        for n := 0 ; n < s.threads ; n++ {
+            select {
+
+            case entries[n] = <- s.ptrChan[n]:
+
+            case <- ctx.Done():
+                return nil, 0, ctx.Err()
+            }
+
+            lifetime_proposal_attempts[n]++
+            if entries[n].accepted {
+                lifetime_proposal_accepts[n]++
+            }
+
+            if entries[n].score == 0 {
+                return entries[n].state, entries[n].score, nil
+            }
+        }
+
+        sweep++
+
+        if sweep % s.SwapInterval == 0 {
+            swap_rounds++
+
+            for n := 0 ; n < s.threads - 1 ; n++ {
+
+                lifetime_swap_attempts[n]++
+                tune_attempts[n]++
+
+                beta_i := 1 / s.temp(n)
+                beta_j := 1 / s.temp(n + 1)
+
+                // Standard replica-exchange (parallel tempering) acceptance criterion.
+                delta := (beta_i - beta_j) * (entries[n].score - entries[n + 1].score)
+
+                if delta >= 0 || s.hubRNG.Float64() < math.Exp(delta) {
+                    entries[n], entries[n + 1] = entries[n + 1], entries[n]
+                    lifetime_swap_accepts[n]++
+                    tune_accepts[n]++
+                }
+            }
+
+            if s.adaptive && swap_rounds % s.tuneEvery == 0 {
+                s.tune(tune_attempts, tune_accepts)
+                for n := range tune_attempts {
+                    tune_attempts[n] = 0
+                    tune_accepts[n] = 0
+                }
+            }
+        }
+
+        for n := 0 ; n < s.threads ; n++ {
+            select {
+            case s.ptrChan[n] <- entries[n]:
+            case <- ctx.Done():
+                return nil, 0, ctx.Err()
+            }
+        }
+
+        if s.Results != nil {
+            s.sendResult(sweep, entries, lifetime_swap_attempts, lifetime_swap_accepts, lifetime_proposal_attempts, lifetime_proposal_accepts)
+        }
+
+        if s.CheckpointPath != "" && sweep % checkpoint_every == 0 {
+            states := make([]State, s.threads)
+            for n, e := range entries {
+                states[n] = e.state
+            }
+            if err := s.Checkpoint(s.CheckpointPath, states); err != nil {
+                fmt.Fprintf(os.Stderr, "mcmc: checkpoint failed: %v\n", err)
+            }
+        }
+    }
+}
+
+// sendResult builds this sweep's Result and sends it on Results without blocking; a slow or
+// absent consumer just misses sweeps rather than stalling the search.
+func (s *Sampler) sendResult(sweep int, entries []*entry, swap_attempts, swap_accepts []int64, proposal_attempts, proposal_accepts []int64) {
+
+    scores := make([]float64, s.threads)
+    best_state := entries[0].state
+    best_score := entries[0].score
+
+    for n, e := range entries {
+        scores[n] = e.score
+        if e.score < best_score {
+            best_score = e.score
+            best_state = e.state
+        }
+    }
+
+    swap_rates := make([]float64, s.threads - 1)
+    for n := range swap_rates {
+        if swap_attempts[n] > 0 {
+            swap_rates[n] = float64(swap_accepts[n]) / float64(swap_attempts[n])
+        }
+    }
+
+    // Chains sharing a kernel name (e.g. several Gaussian chains at different temperatures) are
+    // pooled into a single rate for that name.
+    name_attempts := make(map[string]int64)
+    name_accepts := make(map[string]int64)
+    for n := 0 ; n < s.threads ; n++ {
+        name := s.Proposals[n].Name()
+        name_attempts[name] += proposal_attempts[n]
+        name_accepts[name] += proposal_accepts[n]
+    }
+
+    proposal_rates := make(map[string]float64, len(name_attempts))
+    for name, attempts := range name_attempts {
+        if attempts > 0 {
+            proposal_rates[name] = float64(name_accepts[name]) / float64(attempts)
+        }
+    }
+
+    result := Result{
+        Iter:            sweep,
+        ChainScores:     scores,
+        BestState:       best_state,
+        SwapAccepts:     swap_rates,
+        ProposalAccepts: proposal_rates,
+        Temp:            s.tempSnapshot(),
+    }
+
+    select {
+    case s.Results <- result:
+    default:
+    }
+}
+
+// chain runs chain index's Metropolis loop until ctx is done, so that Run can always stop every
+// chain it started instead of leaving them blocked on ptrChan forever.
+func (s *Sampler) chain(ctx context.Context, index int) {
+
+    var my_entry *entry
+
+    for {
+        select {
+        case my_entry = <- s.ptrChan[index]:
+        case <- ctx.Done():
+            return
+        }
+
+        old_state := my_entry.state
+        old_score := my_entry.score
+
+        rng := s.rngs[index].rng
+
+        candidate := s.Problem.Mutate(s.Problem.Clone(old_state), rng, s.Proposals[index])
+        new_score := s.Problem.Score(candidate)
+
+        delta_e := new_score - old_score
+        accept := delta_e <= 0 || rng.Float64() < math.Exp(-delta_e / s.temp(index))
+
+        if accept {
+            my_entry = &entry{state: candidate, score: new_score, accepted: true}
+        } else {
+            my_entry = &entry{state: old_state, score: old_score, accepted: false}
+        }
+
+        select {
+        case s.ptrChan[index] <- my_entry:
+        case <- ctx.Done():
+            return
+        }
+    }
+}
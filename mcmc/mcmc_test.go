@@ -0,0 +1,205 @@
+package mcmc
+
+import (
+    "context"
+    "encoding/json"
+    "math/rand"
+    "path/filepath"
+    "runtime"
+    "testing"
+    "time"
+)
+
+// findTarget is a synthetic Problem with a known optimum, used to exercise the Sampler without
+// depending on the example binaries.
+type findTarget struct {
+    target int
+}
+
+func (p findTarget) New() State {
+    return 0
+}
+
+func (p findTarget) Score(s State) float64 {
+    diff := p.target - s.(int)
+    if diff < 0 {
+        diff = -diff
+    }
+    return float64(diff)
+}
+
+func (p findTarget) Mutate(s State, r *rand.Rand, proposal Proposal) State {
+    return s.(int) + int(proposal.Step(r))
+}
+
+func (p findTarget) Clone(s State) State {
+    return s
+}
+
+func (p findTarget) Encode(s State) ([]byte, error) {
+    return json.Marshal(s.(int))
+}
+
+func (p findTarget) Decode(data []byte) (State, error) {
+    var n int
+    if err := json.Unmarshal(data, &n); err != nil {
+        return nil, err
+    }
+    return n, nil
+}
+
+func TestSamplerFindsKnownOptimum(t *testing.T) {
+
+    problem := findTarget{target: 100}
+    temp := []float64{0.1, 1, 10, 100}
+
+    sampler := NewSampler(problem, temp)
+
+    ctx, cancel := context.WithTimeout(context.Background(), 10 * time.Second)
+    defer cancel()
+
+    state, score, err := sampler.Run(ctx)
+    if err != nil {
+        t.Fatalf("sampler did not converge in time: %v", err)
+    }
+    if score != 0 {
+        t.Fatalf("expected score 0, got %v", score)
+    }
+    if state.(int) != problem.target {
+        t.Fatalf("expected state %d, got %v", problem.target, state)
+    }
+}
+
+func TestRunDoesNotLeakChainGoroutines(t *testing.T) {
+
+    before := runtime.NumGoroutine()
+
+    // Target found: Run returns via the score == 0 path.
+    found := findTarget{target: 100}
+    if _, _, err := NewSampler(found, []float64{0.1, 1, 10, 100}).Run(context.Background()); err != nil {
+        t.Fatalf("Run: %v", err)
+    }
+
+    // Never found: Run returns via ctx cancellation instead.
+    unreachable := findTarget{target: -1 << 30}
+    ctx, cancel := context.WithTimeout(context.Background(), 10 * time.Millisecond)
+    defer cancel()
+    if _, _, err := NewSampler(unreachable, []float64{0.1, 1, 10, 100}).Run(ctx); err == nil {
+        t.Fatal("expected ctx deadline error, got nil")
+    }
+
+    // Chain goroutines exit asynchronously once they next observe ctx.Done(); give them a moment.
+    var after int
+    for i := 0 ; i < 100 ; i++ {
+        after = runtime.NumGoroutine()
+        if after <= before {
+            break
+        }
+        time.Sleep(10 * time.Millisecond)
+    }
+
+    if after > before {
+        t.Fatalf("goroutine leak: started at %d, still at %d after two Run calls", before, after)
+    }
+}
+
+func TestSetProposalsRejectsInvalidZipf(t *testing.T) {
+
+    sampler := NewSampler(findTarget{target: 100}, []float64{0.1, 1})
+
+    err := sampler.SetProposals([]Proposal{
+        UniformProposal{Width: 10},
+        &ZipfProposal{Max: 5000}, // S left at its zero value, which NewZipf documents as invalid
+    })
+    if err == nil {
+        t.Fatal("expected an error for a ZipfProposal with S <= 1, got nil")
+    }
+}
+
+func TestSetProposalsAcceptsValidKernels(t *testing.T) {
+
+    sampler := NewSampler(findTarget{target: 100}, []float64{0.1, 1})
+
+    err := sampler.SetProposals([]Proposal{
+        UniformProposal{Width: 10},
+        &ZipfProposal{S: 1.5, Max: 5000},
+    })
+    if err != nil {
+        t.Fatalf("SetProposals: %v", err)
+    }
+}
+
+// TestRunRejectsInvalidZipfAssignedDirectly covers the path SetProposals can't: a caller who
+// writes straight into Proposals instead of going through the setter. Run must catch this before
+// starting any chain, not leave it to panic inside a chain goroutine on first mutation.
+func TestRunRejectsInvalidZipfAssignedDirectly(t *testing.T) {
+
+    sampler := NewSampler(findTarget{target: 100}, []float64{0.1, 1})
+    sampler.Proposals[1] = &ZipfProposal{Max: 100} // S left at its zero value
+
+    ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+    defer cancel()
+
+    if _, _, err := sampler.Run(ctx); err == nil {
+        t.Fatal("expected Run to reject a directly-assigned invalid ZipfProposal, got nil error")
+    }
+}
+
+func TestCheckpointResume(t *testing.T) {
+
+    problem := findTarget{target: 100}
+    temp := []float64{0.1, 1, 10}
+
+    sampler := NewSampler(problem, temp)
+    sampler.SwapInterval = 7
+    sampler.adaptive = true
+    sampler.tuneEvery = 5
+    sampler.targetAccept = 0.3
+    sampler.CheckpointPath = "somewhere.json"
+    sampler.CheckpointEvery = 50
+
+    states := []State{10, 20, 30}
+    path := filepath.Join(t.TempDir(), "checkpoint.json")
+
+    if err := sampler.Checkpoint(path, states); err != nil {
+        t.Fatalf("Checkpoint: %v", err)
+    }
+
+    resumed, err := Resume(path, problem)
+    if err != nil {
+        t.Fatalf("Resume: %v", err)
+    }
+
+    if len(resumed.resumeStates) != len(states) {
+        t.Fatalf("expected %d resumed states, got %d", len(states), len(resumed.resumeStates))
+    }
+    for n, want := range states {
+        if resumed.resumeStates[n].(int) != want.(int) {
+            t.Fatalf("chain %d: expected resumed state %v, got %v", n, want, resumed.resumeStates[n])
+        }
+    }
+    for n, want := range temp {
+        if resumed.Temp[n] != want {
+            t.Fatalf("chain %d: expected resumed temp %v, got %v", n, want, resumed.Temp[n])
+        }
+    }
+
+    if resumed.SwapInterval != sampler.SwapInterval {
+        t.Fatalf("expected resumed SwapInterval %v, got %v", sampler.SwapInterval, resumed.SwapInterval)
+    }
+    if resumed.adaptive != sampler.adaptive {
+        t.Fatalf("expected resumed Adaptive %v, got %v", sampler.adaptive, resumed.adaptive)
+    }
+    if resumed.tuneEvery != sampler.tuneEvery {
+        t.Fatalf("expected resumed TuneEvery %v, got %v", sampler.tuneEvery, resumed.tuneEvery)
+    }
+    if resumed.targetAccept != sampler.targetAccept {
+        t.Fatalf("expected resumed TargetAccept %v, got %v", sampler.targetAccept, resumed.targetAccept)
+    }
+    if resumed.CheckpointPath != sampler.CheckpointPath {
+        t.Fatalf("expected resumed CheckpointPath %v, got %v", sampler.CheckpointPath, resumed.CheckpointPath)
+    }
+    if resumed.CheckpointEvery != sampler.CheckpointEvery {
+        t.Fatalf("expected resumed CheckpointEvery %v, got %v", sampler.CheckpointEvery, resumed.CheckpointEvery)
+    }
+}
@@ -0,0 +1,89 @@
+package mcmc
+
+import (
+    "fmt"
+    "math/rand"
+)
+
+// Proposal generates the jump size for a single Metropolis step. Kernels differ in their tails:
+// Uniform and Gaussian stay local around the current state, while Zipf occasionally proposes a
+// long-range jump that can escape a local optimum the local kernels can't climb out of.
+type Proposal interface {
+    Step(r *rand.Rand) float64
+    Name() string
+}
+
+// UniformProposal draws a jump uniformly from [-Width, Width].
+type UniformProposal struct {
+    Width float64
+}
+
+func (p UniformProposal) Step(r *rand.Rand) float64 {
+    return (r.Float64() * 2 - 1) * p.Width
+}
+
+func (p UniformProposal) Name() string {
+    return "uniform"
+}
+
+// GaussianProposal draws a jump from a normal distribution with standard deviation Sigma. Hotter
+// chains should be given a larger Sigma so they explore more widely.
+type GaussianProposal struct {
+    Sigma float64
+}
+
+func (p GaussianProposal) Step(r *rand.Rand) float64 {
+    return r.NormFloat64() * p.Sigma
+}
+
+func (p GaussianProposal) Name() string {
+    return "gaussian"
+}
+
+// ZipfProposal mostly proposes small jumps but with a heavy tail, occasionally proposing a jump
+// as large as Max. S must be > 1; larger S concentrates more probability on small jumps.
+//
+// Building the underlying *rand.Zipf does nontrivial math.Log/Exp/Pow setup, so Step builds it
+// once on first use and caches it rather than paying that cost every sweep. That makes a
+// *ZipfProposal, once used, a single chain's private state: give each chain its own instance
+// rather than sharing one, the same way each chain already gets its own Proposal entry.
+type ZipfProposal struct {
+    S   float64
+    Max uint64
+
+    zipf *rand.Zipf // built by Step on first call; left nil if S <= 1, see Validate
+}
+
+func (p *ZipfProposal) Step(r *rand.Rand) float64 {
+    if p.zipf == nil {
+        p.zipf = rand.NewZipf(r, p.S, 1, p.Max)
+        if p.zipf == nil {
+            // rand.NewZipf returns nil for S <= 1 instead of panicking. Run validates this before starting any chain (see Validate), so this
+            // is belt-and-suspenders: stay a harmless no-op rather than crash the chain goroutine.
+            return 0
+        }
+    }
+
+    jump := float64(p.zipf.Uint64())
+    if r.Float64() < 0.5 {
+        jump = -jump
+    }
+    return jump
+}
+
+func (p *ZipfProposal) Name() string {
+    return "zipf"
+}
+
+// Validate reports whether p's parameters will let Step build a usable *rand.Zipf. Callers don't
+// need to call this themselves: SetProposals and Run both call it on every proposal that
+// implements it, before any chain can reach the nil-Zipf case in Step.
+func (p ZipfProposal) Validate() error {
+    if p.S <= 1 {
+        return fmt.Errorf("mcmc: ZipfProposal.S must be > 1, got %v", p.S)
+    }
+    if p.Max < 1 {
+        return fmt.Errorf("mcmc: ZipfProposal.Max must be >= 1, got %v", p.Max)
+    }
+    return nil
+}
@@ -0,0 +1,61 @@
+package mcmc
+
+import (
+    "math"
+    "math/rand"
+    "testing"
+)
+
+func TestUniformProposalRange(t *testing.T) {
+
+    p := UniformProposal{Width: 10}
+    r := rand.New(rand.NewSource(1))
+
+    for i := 0 ; i < 1000 ; i++ {
+        step := p.Step(r)
+        if step < -10 || step > 10 {
+            t.Fatalf("step %v out of [-10, 10]", step)
+        }
+    }
+}
+
+func TestGaussianProposalZeroSigma(t *testing.T) {
+
+    p := GaussianProposal{Sigma: 0}
+    r := rand.New(rand.NewSource(1))
+
+    if step := p.Step(r); step != 0 {
+        t.Fatalf("expected a zero step with Sigma 0, got %v", step)
+    }
+}
+
+func TestZipfProposalRange(t *testing.T) {
+
+    p := &ZipfProposal{S: 1.5, Max: 5000}
+    r := rand.New(rand.NewSource(1))
+
+    for i := 0 ; i < 1000 ; i++ {
+        step := p.Step(r)
+        if math.Abs(step) > 5000 {
+            t.Fatalf("step %v exceeds Max", step)
+        }
+    }
+}
+
+func TestProposalNames(t *testing.T) {
+
+    cases := []struct {
+        p    Proposal
+        want string
+    }{
+        {UniformProposal{}, "uniform"},
+        {GaussianProposal{}, "gaussian"},
+        {&ZipfProposal{S: 2, Max: 1}, "zipf"},
+    }
+
+    for _, c := range cases {
+        if got := c.p.Name(); got != c.want {
+            t.Fatalf("expected name %q, got %q", c.want, got)
+        }
+    }
+}